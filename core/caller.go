@@ -0,0 +1,181 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+)
+
+// CallerParams configures a Caller.
+type CallerParams struct {
+	Client      *http.Client
+	MaxAttempts uint
+	// Middlewares wrap every request the Caller issues, outermost first.
+	Middlewares []Middleware
+}
+
+// Caller issues HTTP requests on behalf of generated endpoint clients,
+// handling JSON encoding/decoding, error decoding, and retries.
+type Caller struct {
+	client      *http.Client
+	maxAttempts uint
+	middlewares []Middleware
+}
+
+func NewCaller(params *CallerParams) *Caller {
+	client := params.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	maxAttempts := params.MaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = 1
+	}
+	return &Caller{client: client, maxAttempts: maxAttempts, middlewares: params.Middlewares}
+}
+
+// CallParams describes a single endpoint call.
+type CallParams struct {
+	URL          string
+	Method       string
+	MaxAttempts  uint
+	Headers      http.Header
+	Client       *http.Client
+	Request      interface{}
+	Response     interface{}
+	ErrorDecoder func(statusCode int, header http.Header, body io.Reader) error
+	// Middlewares wrap this call in addition to the Caller's own
+	// middlewares (outermost first), so per-call middleware registered via
+	// option.WithMiddleware isn't dropped.
+	Middlewares []Middleware
+
+	// RetryPolicy, if set, governs delay and retryability beyond the
+	// basic MaxAttempts count. A nil RetryPolicy never retries: every
+	// shouldRetryStatus/shouldRetryErr check short-circuits to false, so
+	// Call returns after the first attempt regardless of MaxAttempts.
+	RetryPolicy *RetryPolicy
+	// OnRetry, if set, overrides RetryPolicy.OnRetry for this call.
+	OnRetry func(attempt int, delay time.Duration, err error)
+}
+
+// Call issues the HTTP request described by params, retrying according to
+// params.RetryPolicy (if set) up to params.MaxAttempts times, and decodes
+// the response into params.Response on success or params.ErrorDecoder on
+// failure.
+func (c *Caller) Call(ctx context.Context, params *CallParams) error {
+	client := params.Client
+	if client == nil {
+		client = c.client
+	}
+	maxAttempts := params.MaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = c.maxAttempts
+	}
+
+	var body []byte
+	if params.Request != nil {
+		encoded, err := json.Marshal(params.Request)
+		if err != nil {
+			return err
+		}
+		body = encoded
+	}
+
+	roundTrip := chain(client.Do, append(append([]Middleware(nil), c.middlewares...), params.Middlewares...))
+
+	var lastErr error
+	var retryAfter string
+	for attempt := uint(0); attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			delay := c.retryDelay(params.RetryPolicy, int(attempt)-1, retryAfter)
+			c.notifyRetry(params, int(attempt)-1, delay, lastErr)
+			if err := sleep(ctx, delay); err != nil {
+				return err
+			}
+		}
+		retryAfter = ""
+
+		req, err := http.NewRequestWithContext(ctx, params.Method, params.URL, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		// Each attempt gets its own copy so middleware edits from a failed
+		// attempt don't carry over into the retry.
+		req.Header = params.Headers.Clone()
+
+		resp, err := roundTrip(req)
+		if err != nil {
+			lastErr = err
+			if params.RetryPolicy.shouldRetryErr(err) && attempt+1 < maxAttempts {
+				continue
+			}
+			return err
+		}
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			defer resp.Body.Close()
+			if params.Response == nil {
+				return nil
+			}
+			return json.NewDecoder(resp.Body).Decode(params.Response)
+		}
+
+		retryAfter = resp.Header.Get("Retry-After")
+		decodeErr := c.decodeError(params, resp)
+		resp.Body.Close()
+		lastErr = decodeErr
+
+		if params.RetryPolicy.shouldRetryStatus(resp.StatusCode) && attempt+1 < maxAttempts {
+			continue
+		}
+		return decodeErr
+	}
+
+	return lastErr
+}
+
+func (c *Caller) decodeError(params *CallParams, resp *http.Response) error {
+	if params.ErrorDecoder != nil {
+		return params.ErrorDecoder(resp.StatusCode, resp.Header, resp.Body)
+	}
+	return DecodeAPIError(resp.StatusCode, resp.Header, resp.Body)
+}
+
+// retryDelay computes how long to wait before the next attempt, honoring a
+// Retry-After header over the policy's computed backoff.
+func (c *Caller) retryDelay(policy *RetryPolicy, attempt int, retryAfter string) time.Duration {
+	if policy == nil {
+		return 0
+	}
+	if d, ok := policy.retryAfter(retryAfter); ok {
+		return d
+	}
+	return policy.backoff(attempt)
+}
+
+func (c *Caller) notifyRetry(params *CallParams, attempt int, delay time.Duration, err error) {
+	onRetry := params.OnRetry
+	if onRetry == nil && params.RetryPolicy != nil {
+		onRetry = params.RetryPolicy.OnRetry
+	}
+	if onRetry != nil {
+		onRetry(attempt, delay, err)
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,19 @@
+package core
+
+import "fmt"
+
+// APIError is the error returned for a non-2xx response whose status code
+// has no more specific typed error in DecodeAPIError; Body holds the raw
+// response body.
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func NewAPIError(statusCode int, err error) *APIError {
+	return &APIError{StatusCode: statusCode, Body: err.Error()}
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("status code %d: %s", e.StatusCode, e.Body)
+}
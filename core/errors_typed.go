@@ -0,0 +1,152 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Sentinel errors so callers can use errors.Is(err, core.ErrRateLimited)
+// instead of type-switching or matching on error strings.
+var (
+	ErrBadRequest          = errors.New("bad request")
+	ErrAuthentication      = errors.New("authentication failed")
+	ErrForbidden           = errors.New("forbidden")
+	ErrNotFound            = errors.New("not found")
+	ErrConflict            = errors.New("conflict")
+	ErrUnprocessableEntity = errors.New("unprocessable entity")
+	ErrRateLimited         = errors.New("rate limited")
+	ErrInternalServer      = errors.New("internal server error")
+	ErrBadGateway          = errors.New("bad gateway")
+	ErrServiceUnavailable  = errors.New("service unavailable")
+	ErrGatewayTimeout      = errors.New("gateway timeout")
+)
+
+// errorEnvelope is the standard JSON error body returned by the Zep server.
+type errorEnvelope struct {
+	Code      int    `json:"code"`
+	Message   string `json:"message"`
+	Details   string `json:"details"`
+	RequestID string `json:"request_id"`
+}
+
+// TypedError is the common shape of every typed error core.DecodeAPIError
+// returns for a mapped status code (unmapped codes give an *APIError): the
+// parsed envelope plus the raw body and status code, so
+// callers who need more than errors.Is can still get at the details.
+type TypedError struct {
+	StatusCode int
+	RequestID  string
+	Message    string
+	Details    string
+	Body       []byte
+
+	sentinel error
+}
+
+func (e *TypedError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("status code %d (request_id %s): %s", e.StatusCode, e.RequestID, e.Message)
+	}
+	return fmt.Sprintf("status code %d (request_id %s): %s", e.StatusCode, e.RequestID, string(e.Body))
+}
+
+// Unwrap lets errors.Is(err, core.ErrNotFound) etc. work against the
+// matching sentinel for this error's status code.
+func (e *TypedError) Unwrap() error {
+	return e.sentinel
+}
+
+type BadRequestError struct{ TypedError }
+type AuthenticationError struct{ TypedError }
+type ForbiddenError struct{ TypedError }
+type NotFoundError struct{ TypedError }
+type ConflictError struct{ TypedError }
+type UnprocessableEntityError struct{ TypedError }
+type InternalServerError struct{ TypedError }
+type BadGatewayError struct{ TypedError }
+type ServiceUnavailableError struct{ TypedError }
+type GatewayTimeoutError struct{ TypedError }
+
+// RateLimitError additionally carries the parsed Retry-After header, if
+// the server sent one.
+type RateLimitError struct {
+	TypedError
+	RetryAfter    time.Duration
+	HasRetryAfter bool
+}
+
+// DecodeAPIError reads a standard {code, message, details, request_id}
+// JSON envelope from body and maps statusCode to the matching typed
+// error. If the body can't be parsed as the envelope, the typed error is
+// still returned with an empty Message and the raw body preserved.
+func DecodeAPIError(statusCode int, header http.Header, body io.Reader) error {
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+
+	var envelope errorEnvelope
+	_ = json.NewDecoder(bytes.NewReader(raw)).Decode(&envelope)
+
+	base := TypedError{
+		StatusCode: statusCode,
+		RequestID:  envelope.RequestID,
+		Message:    envelope.Message,
+		Details:    envelope.Details,
+		Body:       raw,
+	}
+	if base.RequestID == "" && header != nil {
+		base.RequestID = header.Get("X-Request-ID")
+	}
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		base.sentinel = ErrBadRequest
+		return &BadRequestError{base}
+	case http.StatusUnauthorized:
+		base.sentinel = ErrAuthentication
+		return &AuthenticationError{base}
+	case http.StatusForbidden:
+		base.sentinel = ErrForbidden
+		return &ForbiddenError{base}
+	case http.StatusNotFound:
+		base.sentinel = ErrNotFound
+		return &NotFoundError{base}
+	case http.StatusConflict:
+		base.sentinel = ErrConflict
+		return &ConflictError{base}
+	case http.StatusUnprocessableEntity:
+		base.sentinel = ErrUnprocessableEntity
+		return &UnprocessableEntityError{base}
+	case http.StatusTooManyRequests:
+		base.sentinel = ErrRateLimited
+		retryAfter, ok := (&RetryPolicy{}).retryAfter(headerGet(header, "Retry-After"))
+		return &RateLimitError{TypedError: base, RetryAfter: retryAfter, HasRetryAfter: ok}
+	case http.StatusInternalServerError:
+		base.sentinel = ErrInternalServer
+		return &InternalServerError{base}
+	case http.StatusBadGateway:
+		base.sentinel = ErrBadGateway
+		return &BadGatewayError{base}
+	case http.StatusServiceUnavailable:
+		base.sentinel = ErrServiceUnavailable
+		return &ServiceUnavailableError{base}
+	case http.StatusGatewayTimeout:
+		base.sentinel = ErrGatewayTimeout
+		return &GatewayTimeoutError{base}
+	default:
+		return NewAPIError(statusCode, fmt.Errorf("%s", raw))
+	}
+}
+
+func headerGet(header http.Header, key string) string {
+	if header == nil {
+		return ""
+	}
+	return header.Get(key)
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDecodeAPIErrorStatusMapping(t *testing.T) {
+	cases := []struct {
+		status   int
+		sentinel error
+		check    func(error) bool
+	}{
+		{http.StatusBadRequest, ErrBadRequest, func(err error) bool { _, ok := err.(*BadRequestError); return ok }},
+		{http.StatusUnauthorized, ErrAuthentication, func(err error) bool { _, ok := err.(*AuthenticationError); return ok }},
+		{http.StatusForbidden, ErrForbidden, func(err error) bool { _, ok := err.(*ForbiddenError); return ok }},
+		{http.StatusNotFound, ErrNotFound, func(err error) bool { _, ok := err.(*NotFoundError); return ok }},
+		{http.StatusConflict, ErrConflict, func(err error) bool { _, ok := err.(*ConflictError); return ok }},
+		{http.StatusUnprocessableEntity, ErrUnprocessableEntity, func(err error) bool { _, ok := err.(*UnprocessableEntityError); return ok }},
+		{http.StatusInternalServerError, ErrInternalServer, func(err error) bool { _, ok := err.(*InternalServerError); return ok }},
+		{http.StatusBadGateway, ErrBadGateway, func(err error) bool { _, ok := err.(*BadGatewayError); return ok }},
+		{http.StatusServiceUnavailable, ErrServiceUnavailable, func(err error) bool { _, ok := err.(*ServiceUnavailableError); return ok }},
+		{http.StatusGatewayTimeout, ErrGatewayTimeout, func(err error) bool { _, ok := err.(*GatewayTimeoutError); return ok }},
+	}
+
+	for _, c := range cases {
+		t.Run(http.StatusText(c.status), func(t *testing.T) {
+			body := strings.NewReader(`{"message":"boom","request_id":"req-1"}`)
+			err := DecodeAPIError(c.status, nil, body)
+			if !c.check(err) {
+				t.Errorf("DecodeAPIError(%d, ...) returned %T, want the matching typed error", c.status, err)
+			}
+			if !errors.Is(err, c.sentinel) {
+				t.Errorf("errors.Is(err, sentinel) = false for status %d", c.status)
+			}
+		})
+	}
+}
+
+func TestDecodeAPIErrorRateLimitParsesRetryAfter(t *testing.T) {
+	header := http.Header{}
+	header.Set("Retry-After", "7")
+	body := strings.NewReader(`{"message":"slow down"}`)
+
+	err := DecodeAPIError(http.StatusTooManyRequests, header, body)
+	rle, ok := err.(*RateLimitError)
+	if !ok {
+		t.Fatalf("got %T, want *RateLimitError", err)
+	}
+	if !rle.HasRetryAfter {
+		t.Error("HasRetryAfter = false, want true")
+	}
+	if rle.RetryAfter.Seconds() != 7 {
+		t.Errorf("RetryAfter = %v, want 7s", rle.RetryAfter)
+	}
+	if !errors.Is(err, ErrRateLimited) {
+		t.Error("errors.Is(err, ErrRateLimited) = false")
+	}
+}
+
+func TestDecodeAPIErrorFallsBackToRequestIDHeader(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Request-ID", "from-header")
+	body := strings.NewReader(`{"message":"boom"}`)
+
+	err := DecodeAPIError(http.StatusNotFound, header, body)
+	nfe, ok := err.(*NotFoundError)
+	if !ok {
+		t.Fatalf("got %T, want *NotFoundError", err)
+	}
+	if nfe.RequestID != "from-header" {
+		t.Errorf("RequestID = %q, want %q", nfe.RequestID, "from-header")
+	}
+}
+
+func TestDecodeAPIErrorUnknownStatusFallsBackToAPIError(t *testing.T) {
+	body := strings.NewReader(`not json at all`)
+	err := DecodeAPIError(http.StatusTeapot, nil, body)
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("got %T, want *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusTeapot {
+		t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, http.StatusTeapot)
+	}
+}
@@ -0,0 +1,14 @@
+package core
+
+import "net/http"
+
+// MergeHeaders merges src into dst, with src taking precedence on
+// conflicting keys, and returns dst.
+func MergeHeaders(dst, src http.Header) http.Header {
+	for key, values := range src {
+		for _, value := range values {
+			dst.Set(key, value)
+		}
+	}
+	return dst
+}
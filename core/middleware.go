@@ -0,0 +1,22 @@
+package core
+
+import "net/http"
+
+// RoundTripFunc is the signature of the innermost transport call a
+// Middleware wraps.
+type RoundTripFunc func(*http.Request) (*http.Response, error)
+
+// Middleware wraps a RoundTripFunc with cross-cutting behavior (logging,
+// retries, auth, ...), returning a new RoundTripFunc that runs its own
+// logic before and/or after delegating to next.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// chain composes middlewares into a single RoundTripFunc around final, in
+// onion order: middlewares[0] is outermost and runs first.
+func chain(final RoundTripFunc, middlewares []Middleware) RoundTripFunc {
+	rt := final
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		rt = middlewares[i](rt)
+	}
+	return rt
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/getzep/zep-go/core"
+)
+
+// TokenProvider lazily produces a bearer token and the time it expires at.
+// It is called again once the previously returned token is within its
+// expiry slack, mirroring the client's health-status caching pattern.
+type TokenProvider func(ctx context.Context) (token string, expiresAt time.Time, err error)
+
+// AuthSlack is subtracted from a token's expiresAt so Auth refreshes it
+// slightly before the server would reject it.
+const AuthSlack = 5 * time.Second
+
+// Auth injects a bearer token obtained from provider into every request,
+// refreshing it once it is within AuthSlack of expiring. Concurrent
+// requests coalesce onto a single refresh.
+func Auth(provider TokenProvider) core.Middleware {
+	var mu sync.Mutex
+	var token string
+	var expiresAt time.Time
+
+	return func(next core.RoundTripFunc) core.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			fresh := token != "" && time.Now().Before(expiresAt.Add(-AuthSlack))
+			if !fresh {
+				newToken, newExpiresAt, err := provider(req.Context())
+				if err != nil {
+					mu.Unlock()
+					return nil, err
+				}
+				token, expiresAt = newToken, newExpiresAt
+			}
+			current := token
+			mu.Unlock()
+
+			req.Header.Set("Authorization", "Bearer "+current)
+			return next(req)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/getzep/zep-go/core"
+)
+
+// Logger is the subset of *log.Logger that Logging needs, so callers can
+// plug in any structured logger that supports it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// Logging logs a line before and after each request with method, URL,
+// status code, and latency. It defaults to the standard library logger
+// when logger is nil.
+func Logging(logger Logger) core.Middleware {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return func(next core.RoundTripFunc) core.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			logger.Printf("zep: -> %s %s", req.Method, req.URL)
+
+			resp, err := next(req)
+
+			latency := time.Since(start)
+			if err != nil {
+				logger.Printf("zep: <- %s %s error=%v latency=%s", req.Method, req.URL, err, latency)
+				return resp, err
+			}
+			logger.Printf("zep: <- %s %s status=%d latency=%s", req.Method, req.URL, resp.StatusCode, latency)
+			return resp, err
+		}
+	}
+}
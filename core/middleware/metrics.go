@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/getzep/zep-go/core"
+)
+
+// Recorder receives request outcomes from Metrics. Implementations can
+// wire these into Prometheus, StatsD, or any other metrics backend.
+type Recorder interface {
+	// IncRequests increments a counter for a request with the given
+	// method and status code (0 if the request errored before a status
+	// was received).
+	IncRequests(method string, statusCode int)
+	// ObserveLatency records how long the request took.
+	ObserveLatency(method string, statusCode int, d time.Duration)
+}
+
+// Metrics reports request counts and latency histograms to recorder.
+func Metrics(recorder Recorder) core.Middleware {
+	return func(next core.RoundTripFunc) core.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(req)
+			latency := time.Since(start)
+
+			statusCode := 0
+			if resp != nil {
+				statusCode = resp.StatusCode
+			}
+			recorder.IncRequests(req.Method, statusCode)
+			recorder.ObserveLatency(req.Method, statusCode, latency)
+
+			return resp, err
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/getzep/zep-go/core"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader is the header RequestID injects into outgoing requests.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID injects a random X-Request-ID header into every outgoing
+// request (unless the caller already set one), so it can be correlated
+// with server logs. It does not modify returned errors itself; pair it
+// with a typed error that carries RequestID (see core.DecodeAPIError) to
+// have it echoed back.
+func RequestID() core.Middleware {
+	return func(next core.RoundTripFunc) core.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get(RequestIDHeader) == "" {
+				req.Header.Set(RequestIDHeader, uuid.NewString())
+			}
+			return next(req)
+		}
+	}
+}
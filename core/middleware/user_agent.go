@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/getzep/zep-go/core"
+)
+
+// SDKVersion is the version string UserAgent prepends to the client's
+// User-Agent header.
+const SDKVersion = "0.1.0"
+
+// UserAgent prepends "zep-go/<SDKVersion>" to the outgoing User-Agent
+// header, preserving anything the caller already set.
+func UserAgent() core.Middleware {
+	return func(next core.RoundTripFunc) core.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			prefix := "zep-go/" + SDKVersion
+			if existing := req.Header.Get("User-Agent"); existing != "" {
+				req.Header.Set("User-Agent", prefix+" "+existing)
+			} else {
+				req.Header.Set("User-Agent", prefix)
+			}
+			return next(req)
+		}
+	}
+}
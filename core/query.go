@@ -0,0 +1,49 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// QueryValues marshals request into JSON and flattens its top-level fields
+// into url.Values, skipping null fields and empty strings so unset optional
+// fields don't pollute the query string. Other zero values (0, false) are
+// sent as-is.
+func QueryValues(request interface{}) (url.Values, error) {
+	values := url.Values{}
+	if request == nil {
+		return values, nil
+	}
+
+	raw, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+
+	for key, value := range fields {
+		if value == nil {
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			if v == "" {
+				continue
+			}
+			values.Set(key, v)
+		case []interface{}:
+			for _, item := range v {
+				values.Add(key, fmt.Sprintf("%v", item))
+			}
+		default:
+			values.Set(key, fmt.Sprintf("%v", v))
+		}
+	}
+
+	return values, nil
+}
@@ -0,0 +1,52 @@
+package core
+
+import "net/http"
+
+// DefaultMaxAttempts is used when no option sets MaxAttempts.
+const DefaultMaxAttempts uint = 3
+
+// RequestOptions holds the resolved configuration applied to a generated
+// endpoint call, built up by applying a list of RequestOption funcs.
+type RequestOptions struct {
+	BaseURL     string
+	HTTPClient  *http.Client
+	MaxAttempts uint
+	Token       string
+	Headers     http.Header
+	RetryPolicy *RetryPolicy
+	Middlewares []Middleware
+}
+
+// RequestOption configures a RequestOptions. It is defined here, rather
+// than in the option package, so that generated endpoint code can depend
+// solely on core while the higher-level option package (which needs to
+// know about RetryPolicy and Middleware to build its With* constructors)
+// depends on core instead of the other way around.
+type RequestOption func(*RequestOptions)
+
+// NewRequestOptions builds a RequestOptions from the given options,
+// applying repo-wide defaults first.
+func NewRequestOptions(opts ...RequestOption) *RequestOptions {
+	options := &RequestOptions{
+		MaxAttempts: DefaultMaxAttempts,
+		Headers:     http.Header{},
+		RetryPolicy: DefaultRetryPolicy(),
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// ToHeader returns the headers that should be merged onto an outgoing
+// request, including the bearer token if one was set.
+func (r *RequestOptions) ToHeader() http.Header {
+	header := r.Headers.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	if r.Token != "" {
+		header.Set("Authorization", "Bearer "+r.Token)
+	}
+	return header
+}
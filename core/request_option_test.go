@@ -0,0 +1,25 @@
+package core
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestNewRequestOptionsAppliesDefaultRetryPolicy(t *testing.T) {
+	options := NewRequestOptions()
+	if options.RetryPolicy == nil {
+		t.Fatal("RetryPolicy = nil, want DefaultRetryPolicy() applied by default")
+	}
+	if !options.RetryPolicy.shouldRetryStatus(http.StatusServiceUnavailable) {
+		t.Error("default RetryPolicy does not retry 503, want it to")
+	}
+}
+
+func TestNewRequestOptionsRetryPolicyOptOut(t *testing.T) {
+	options := NewRequestOptions(func(o *RequestOptions) {
+		o.RetryPolicy = nil
+	})
+	if options.RetryPolicy != nil {
+		t.Error("RetryPolicy override to nil was not honored, want retries to be opt-out-able")
+	}
+}
@@ -0,0 +1,112 @@
+package core
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how Caller.Call spaces out retries of a failed
+// request: which statuses/errors are worth retrying, how long to wait
+// between attempts, and an optional hook for observing retries.
+type RetryPolicy struct {
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+	Multiplier float64
+	// Jitter is the fraction, in [0,1], of the computed delay that is
+	// randomly added or subtracted to avoid thundering-herd retries.
+	Jitter float64
+
+	RetryableStatuses     map[int]bool
+	RetryableErrPredicate func(error) bool
+
+	// OnRetry, if set, is called after a retryable failure and before the
+	// sleep for the next attempt, so callers can log retries.
+	OnRetry func(attempt int, delay time.Duration, err error)
+}
+
+// DefaultRetryPolicy retries 429/502/503/504 and timeout errors, with a
+// 250ms base delay doubling up to 10s and 20% jitter.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		BaseDelay:  250 * time.Millisecond,
+		MaxDelay:   10 * time.Second,
+		Multiplier: 2,
+		Jitter:     0.2,
+		RetryableStatuses: map[int]bool{
+			http.StatusTooManyRequests:    true,
+			http.StatusBadGateway:         true,
+			http.StatusServiceUnavailable: true,
+			http.StatusGatewayTimeout:     true,
+		},
+		RetryableErrPredicate: func(err error) bool {
+			var netErr net.Error
+			return errors.As(err, &netErr) && netErr.Timeout()
+		},
+	}
+}
+
+func (p *RetryPolicy) shouldRetryStatus(statusCode int) bool {
+	if p == nil {
+		return false
+	}
+	return p.RetryableStatuses[statusCode]
+}
+
+func (p *RetryPolicy) shouldRetryErr(err error) bool {
+	if p == nil || err == nil || p.RetryableErrPredicate == nil {
+		return false
+	}
+	return p.RetryableErrPredicate(err)
+}
+
+// backoff computes the delay before the given (zero-indexed) retry attempt,
+// applying the exponential multiplier and jitter.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := float64(p.BaseDelay) * math.Pow(p.Multiplier, float64(attempt))
+	if max := float64(p.MaxDelay); delay > max {
+		delay = max
+	}
+	if p.Jitter > 0 {
+		delta := delay * p.Jitter
+		delay += (rand.Float64()*2 - 1) * delta
+	}
+	if delay < 0 {
+		delay = 0
+	}
+	d := time.Duration(delay)
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d
+}
+
+// retryAfter parses a Retry-After header, which may be either a number of
+// seconds or an HTTP-date, capped by MaxDelay.
+func (p *RetryPolicy) retryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		d := time.Duration(seconds) * time.Second
+		if p.MaxDelay > 0 && d > p.MaxDelay {
+			d = p.MaxDelay
+		}
+		return d, true
+	}
+	if when, err := http.ParseTime(header); err == nil {
+		d := time.Until(when)
+		if d < 0 {
+			d = 0
+		}
+		if p.MaxDelay > 0 && d > p.MaxDelay {
+			d = p.MaxDelay
+		}
+		return d, true
+	}
+	return 0, false
+}
@@ -0,0 +1,84 @@
+package core
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestBackoffGrowsAndCaps(t *testing.T) {
+	p := &RetryPolicy{
+		BaseDelay:  100 * time.Millisecond,
+		MaxDelay:   1 * time.Second,
+		Multiplier: 2,
+	}
+
+	if got := p.backoff(0); got != 100*time.Millisecond {
+		t.Errorf("backoff(0) = %v, want %v", got, 100*time.Millisecond)
+	}
+	if got := p.backoff(1); got != 200*time.Millisecond {
+		t.Errorf("backoff(1) = %v, want %v", got, 200*time.Millisecond)
+	}
+	if got := p.backoff(10); got != p.MaxDelay {
+		t.Errorf("backoff(10) = %v, want capped at %v", got, p.MaxDelay)
+	}
+}
+
+func TestBackoffJitterStaysWithinBounds(t *testing.T) {
+	p := &RetryPolicy{
+		BaseDelay:  1 * time.Second,
+		MaxDelay:   10 * time.Second,
+		Multiplier: 1,
+		Jitter:     0.2,
+	}
+	for i := 0; i < 50; i++ {
+		d := p.backoff(0)
+		if d < 800*time.Millisecond || d > 1200*time.Millisecond {
+			t.Fatalf("backoff(0) = %v, want within 20%% of 1s", d)
+		}
+	}
+}
+
+func TestRetryAfterSeconds(t *testing.T) {
+	p := &RetryPolicy{MaxDelay: 30 * time.Second}
+	d, ok := p.retryAfter("5")
+	if !ok {
+		t.Fatal("expected ok=true for numeric Retry-After")
+	}
+	if d != 5*time.Second {
+		t.Errorf("retryAfter(\"5\") = %v, want 5s", d)
+	}
+}
+
+func TestRetryAfterSecondsCappedByMaxDelay(t *testing.T) {
+	p := &RetryPolicy{MaxDelay: 2 * time.Second}
+	d, ok := p.retryAfter("60")
+	if !ok {
+		t.Fatal("expected ok=true")
+	}
+	if d != p.MaxDelay {
+		t.Errorf("retryAfter(\"60\") = %v, want capped at %v", d, p.MaxDelay)
+	}
+}
+
+func TestRetryAfterHTTPDate(t *testing.T) {
+	p := &RetryPolicy{MaxDelay: 1 * time.Hour}
+	when := time.Now().Add(10 * time.Second).UTC()
+	d, ok := p.retryAfter(when.Format(http.TimeFormat))
+	if !ok {
+		t.Fatal("expected ok=true for HTTP-date Retry-After")
+	}
+	if d <= 0 || d > 11*time.Second {
+		t.Errorf("retryAfter(date) = %v, want roughly 10s", d)
+	}
+}
+
+func TestRetryAfterInvalid(t *testing.T) {
+	p := &RetryPolicy{}
+	if _, ok := p.retryAfter("not-a-valid-header"); ok {
+		t.Error("expected ok=false for an unparseable Retry-After header")
+	}
+	if _, ok := p.retryAfter(""); ok {
+		t.Error("expected ok=false for an empty Retry-After header")
+	}
+}
@@ -1,10 +1,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"github.com/getzep/zep-go/core"
 	"github.com/getzep/zep-go/zep"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Masterminds/semver/v3"
@@ -16,6 +19,12 @@ const (
 	MinServerVersion      = "0.16.0"
 	MinServerWarningMsg   = "You are using an incompatible Zep server version. Please upgrade to " + MinServerVersion + " or later."
 	DefaultRequestTimeout = 30 // In seconds
+
+	// DefaultHealthTTL is how long a cached ServerStatus is considered fresh.
+	DefaultHealthTTL = 30 * time.Second
+	// DefaultHealthSlack is subtracted from ExpiresAt so callers re-probe
+	// slightly before the cached status is actually stale.
+	DefaultHealthSlack = 2 * time.Second
 )
 
 var _ ZepClient = &DefaultZepClient{}
@@ -23,12 +32,53 @@ var _ ZepClient = &DefaultZepClient{}
 type ZepClient interface {
 	GetFullURL(endpoint string) string
 	CheckServer() error
+	Status(ctx context.Context) (ServerStatus, error)
 	HandleRequest(requestPromise *http.Request, notFoundMessage string) (*http.Response, error)
+	Close()
+}
+
+// ServerStatus is a point-in-time snapshot of the Zep server's health and
+// version, along with the window during which it is considered fresh.
+type ServerStatus struct {
+	Version   string
+	Healthy   bool
+	CheckedAt time.Time
+	ExpiresAt time.Time
+}
+
+// ClientOption configures a DefaultZepClient at construction time.
+type ClientOption func(*DefaultZepClient)
+
+// WithHealthTTL sets how long a probed ServerStatus is cached before
+// Status will re-probe the server. Defaults to DefaultHealthTTL.
+func WithHealthTTL(ttl time.Duration) ClientOption {
+	return func(c *DefaultZepClient) {
+		c.healthTTL = ttl
+	}
+}
+
+// WithHealthSlack sets how much earlier than ExpiresAt Status should treat
+// the cached status as stale, so a slow caller doesn't trip over an
+// in-flight expiry. Defaults to DefaultHealthSlack.
+func WithHealthSlack(slack time.Duration) ClientOption {
+	return func(c *DefaultZepClient) {
+		c.healthSlack = slack
+	}
+}
+
+// WithBackgroundHealthCheck starts a goroutine that refreshes the cached
+// ServerStatus every interval, so Status and HandleRequest never need to
+// block on a probe in the common case. The goroutine stops when Close is
+// called.
+func WithBackgroundHealthCheck(interval time.Duration) ClientOption {
+	return func(c *DefaultZepClient) {
+		c.backgroundInterval = interval
+	}
 }
 
 // NewZepClient creates a new ZepClient. If client is provided, it will be used to make requests.
 // Otherwise, a default client will be created with a 30 second timeout.
-func NewZepClient(serverURL string, apiKey string, client *http.Client) *DefaultZepClient {
+func NewZepClient(serverURL string, apiKey string, client *http.Client, opts ...ClientOption) *DefaultZepClient {
 	headers := make(map[string]string)
 	if apiKey != "" {
 		headers["Authorization"] = "Bearer " + apiKey
@@ -40,12 +90,26 @@ func NewZepClient(serverURL string, apiKey string, client *http.Client) *Default
 	// Remove trailing slash from server URL
 	serverURL = strings.TrimSuffix(serverURL, "/")
 
-	c := &DefaultZepClient{ServerURL: serverURL, Headers: headers, Client: client}
+	c := &DefaultZepClient{
+		ServerURL:   serverURL,
+		Headers:     headers,
+		Client:      client,
+		healthTTL:   DefaultHealthTTL,
+		healthSlack: DefaultHealthSlack,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
 	err := c.CheckServer()
 	if err != nil {
 		fmt.Println(err)
 	}
 
+	if c.backgroundInterval > 0 {
+		c.startBackgroundHealthCheck()
+	}
+
 	return c
 }
 
@@ -54,6 +118,17 @@ type DefaultZepClient struct {
 	ServerURL string
 	Headers   map[string]string
 	Client    *http.Client
+
+	healthTTL          time.Duration
+	healthSlack        time.Duration
+	backgroundInterval time.Duration
+
+	statusMu sync.Mutex
+	status   ServerStatus
+
+	closeOnce sync.Once
+	closeCh   chan struct{}
+	wg        sync.WaitGroup
 }
 
 // GetFullURL returns the full URL for the given endpoint.
@@ -63,14 +138,53 @@ func (z *DefaultZepClient) GetFullURL(endpoint string) string {
 }
 
 // CheckServer checks if the server is running and returns an error if it is not.
-// It also checks if the server version is compatible with this client.
+// It also checks if the server version is compatible with this client. The
+// result is cached (see Status) so later callers don't re-probe on every
+// request.
 func (z *DefaultZepClient) CheckServer() error {
-	healthCheck := "/healthz"
-	healthCheckURL := z.ServerURL + healthCheck
+	_, err := z.probe()
+	return err
+}
+
+// Status returns the cached ServerStatus if it is still fresh (now is more
+// than healthSlack before ExpiresAt), otherwise it re-probes /healthz under
+// a mutex so that concurrent callers coalesce onto a single request.
+func (z *DefaultZepClient) Status(ctx context.Context) (ServerStatus, error) {
+	if cached, fresh := z.cachedStatus(); fresh {
+		return cached, nil
+	}
+	return z.probe()
+}
+
+// cachedStatus returns the cached status and whether it is still fresh.
+func (z *DefaultZepClient) cachedStatus() (ServerStatus, bool) {
+	z.statusMu.Lock()
+	defer z.statusMu.Unlock()
+	cached := z.status
+	fresh := !cached.ExpiresAt.IsZero() && time.Now().Before(cached.ExpiresAt.Add(-z.healthSlack))
+	return cached, fresh
+}
+
+// probe hits /healthz, updates the cached ServerStatus and returns it. It
+// holds statusMu for the duration of the request so concurrent Status/
+// CheckServer calls coalesce onto a single in-flight probe: whichever
+// caller takes the lock first performs the request, and everyone else
+// that was waiting on the lock finds the status fresh again once they
+// acquire it and returns the result of that single probe instead of
+// issuing their own.
+func (z *DefaultZepClient) probe() (ServerStatus, error) {
+	z.statusMu.Lock()
+	defer z.statusMu.Unlock()
+
+	if cached := z.status; !cached.ExpiresAt.IsZero() && time.Now().Before(cached.ExpiresAt.Add(-z.healthSlack)) {
+		return cached, nil
+	}
+
+	healthCheckURL := z.ServerURL + "/healthz"
 
 	req, err := http.NewRequest("GET", healthCheckURL, nil)
 	if err != nil {
-		return err
+		return z.status, err
 	}
 	for key, value := range z.Headers {
 		req.Header.Add(key, value)
@@ -78,45 +192,93 @@ func (z *DefaultZepClient) CheckServer() error {
 
 	resp, err := z.Client.Do(req)
 	if err != nil {
-		return err
+		now := time.Now()
+		z.status = ServerStatus{Healthy: false, CheckedAt: now, ExpiresAt: now.Add(z.healthTTL)}
+		return z.status, err
 	}
 
 	zepServerVersion := resp.Header.Get("X-Zep-Version")
+	now := time.Now()
+	z.status = ServerStatus{
+		Version:   zepServerVersion,
+		Healthy:   resp.StatusCode == http.StatusOK,
+		CheckedAt: now,
+		ExpiresAt: now.Add(z.healthTTL),
+	}
+
 	meetsMinVersion, err := isVersionGreaterOrEqual(zepServerVersion)
 	if err != nil {
-		return err
+		return z.status, err
 	}
 	if !meetsMinVersion {
 		fmt.Println("Warning: " + MinServerWarningMsg)
 	}
-	if resp.StatusCode != 200 {
-		return &zep.ZepError{Message: ServerErrorMessage}
+
+	if !z.status.Healthy {
+		return z.status, &zep.ZepError{Message: ServerErrorMessage}
 	}
+	return z.status, nil
+}
+
+// startBackgroundHealthCheck launches the goroutine that keeps the cached
+// ServerStatus warm. It is stopped by Close.
+func (z *DefaultZepClient) startBackgroundHealthCheck() {
+	z.closeCh = make(chan struct{})
+	ticker := time.NewTicker(z.backgroundInterval)
+	z.wg.Add(1)
+	go func() {
+		defer z.wg.Done()
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				_, _ = z.probe()
+			case <-z.closeCh:
+				return
+			}
+		}
+	}()
+}
 
-	return nil
+// Close stops the background health check goroutine started by
+// WithBackgroundHealthCheck, if any. It is safe to call multiple times and
+// safe to call on a client that never started one.
+func (z *DefaultZepClient) Close() {
+	z.closeOnce.Do(func() {
+		if z.closeCh != nil {
+			close(z.closeCh)
+		}
+	})
+	z.wg.Wait()
 }
 
 // HandleRequest makes the request and returns the response if the request is successful.
-// If the request is not successful, it returns an appropriate error:
-// - NotFoundError if the status code is 404
-// - AuthenticationError if the status code is 401
-// - APIError if the status code is anything else
+// If the cached server status shows the server as unhealthy, the request is
+// short-circuited with a ServerUnavailableError instead of hitting the
+// network. Otherwise, a non-200 response is run through
+// core.DecodeAPIError so callers get the same typed errors (NotFoundError,
+// AuthenticationError, RateLimitError, ...) as the generated endpoint
+// clients, rather than string-matching on messages.
 func (z *DefaultZepClient) HandleRequest(requestPromise *http.Request, notFoundMessage string) (*http.Response, error) {
+	if status, _ := z.Status(requestPromise.Context()); !status.Healthy {
+		return nil, &zep.ServerUnavailableError{ZepError: zep.ZepError{Message: ServerErrorMessage}}
+	}
+
 	response, err := z.Client.Do(requestPromise)
 	if err != nil {
 		return nil, &zep.ZepError{Message: ServerErrorMessage + ": " + err.Error()}
 	}
 
-	switch response.StatusCode {
-	case http.StatusOK:
+	if response.StatusCode == http.StatusOK {
 		return response, nil
-	case http.StatusNotFound:
-		return nil, &zep.NotFoundError{ZepError: zep.ZepError{Message: notFoundMessage}}
-	case http.StatusUnauthorized:
-		return nil, &zep.AuthenticationError{ZepError: zep.ZepError{Message: "Authentication failed."}}
-	default:
-		return nil, &zep.APIError{ZepError: zep.ZepError{Message: fmt.Sprintf("Got an unexpected status code: %d", response.StatusCode)}}
 	}
+
+	decoded := core.DecodeAPIError(response.StatusCode, response.Header, response.Body)
+	response.Body.Close()
+	if notFound, ok := decoded.(*core.NotFoundError); ok && notFound.Message == "" {
+		notFound.Message = notFoundMessage
+	}
+	return nil, decoded
 }
 
 func isVersionGreaterOrEqual(version string) (bool, error) {
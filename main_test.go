@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestServer(t *testing.T) (*httptest.Server, *int32) {
+	t.Helper()
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("X-Zep-Version", MinServerVersion)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, &hits
+}
+
+func TestStatusReturnsFreshCacheWithoutProbing(t *testing.T) {
+	server, hits := newTestServer(t)
+
+	now := time.Now()
+	client := &DefaultZepClient{
+		ServerURL:   server.URL,
+		Client:      http.DefaultClient,
+		healthTTL:   DefaultHealthTTL,
+		healthSlack: DefaultHealthSlack,
+		status: ServerStatus{
+			Healthy:   true,
+			CheckedAt: now,
+			ExpiresAt: now.Add(DefaultHealthTTL),
+		},
+	}
+
+	status, err := client.Status(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !status.Healthy {
+		t.Error("status.Healthy = false, want true")
+	}
+	if got := atomic.LoadInt32(hits); got != 0 {
+		t.Errorf("server was probed %d times, want 0 (cache should have been used)", got)
+	}
+}
+
+func TestStatusWithinSlackIsTreatedAsStale(t *testing.T) {
+	server, hits := newTestServer(t)
+
+	now := time.Now()
+	client := &DefaultZepClient{
+		ServerURL:   server.URL,
+		Client:      http.DefaultClient,
+		healthTTL:   DefaultHealthTTL,
+		healthSlack: DefaultHealthSlack,
+		status: ServerStatus{
+			Healthy:   true,
+			CheckedAt: now,
+			// ExpiresAt is within healthSlack of now, so Status should
+			// re-probe rather than return this nearly-stale value.
+			ExpiresAt: now.Add(DefaultHealthSlack / 2),
+		},
+	}
+
+	if _, err := client.Status(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := atomic.LoadInt32(hits); got != 1 {
+		t.Errorf("server was probed %d times, want 1", got)
+	}
+}
+
+func TestStatusCachesUnhealthyAfterTransportError(t *testing.T) {
+	// Point at a server that isn't listening so Client.Do fails outright -
+	// the actual outage case CheckServer/Status are meant to cache.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to reserve an address: %v", err)
+	}
+	unreachableURL := "http://" + listener.Addr().String()
+	listener.Close()
+
+	client := &DefaultZepClient{
+		ServerURL:   unreachableURL,
+		Client:      &http.Client{Timeout: 200 * time.Millisecond},
+		healthTTL:   DefaultHealthTTL,
+		healthSlack: DefaultHealthSlack,
+	}
+
+	status, err := client.Status(context.Background())
+	if err == nil {
+		t.Fatal("expected an error probing an unreachable server")
+	}
+	if status.Healthy {
+		t.Error("status.Healthy = true, want false")
+	}
+
+	cached, fresh := client.cachedStatus()
+	if !fresh {
+		t.Fatal("cachedStatus() reported stale right after a failed probe; the unhealthy status should be cached for healthTTL so callers short-circuit instead of re-probing")
+	}
+	if cached.Healthy {
+		t.Error("cached.Healthy = true, want false")
+	}
+}
+
+func TestStatusCoalescesConcurrentProbes(t *testing.T) {
+	server, hits := newTestServer(t)
+
+	client := &DefaultZepClient{
+		ServerURL:   server.URL,
+		Client:      http.DefaultClient,
+		healthTTL:   DefaultHealthTTL,
+		healthSlack: DefaultHealthSlack,
+	}
+
+	const n = 20
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			if _, err := client.Status(context.Background()); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(hits); got != 1 {
+		t.Errorf("server was probed %d times, want exactly 1 (concurrent callers should coalesce)", got)
+	}
+}
@@ -0,0 +1,71 @@
+package option
+
+import (
+	"net/http"
+
+	"github.com/getzep/zep-go/core"
+)
+
+// RequestOption configures a generated endpoint call. It is an alias for
+// core.RequestOption so the With* constructors below and core.NewRequestOptions agree
+// on the same underlying type without option and core importing each
+// other in a cycle.
+type RequestOption = core.RequestOption
+
+// WithBaseURL overrides the server URL generated endpoint clients send
+// requests to.
+func WithBaseURL(baseURL string) RequestOption {
+	return func(options *core.RequestOptions) {
+		options.BaseURL = baseURL
+	}
+}
+
+// WithHTTPClient overrides the *http.Client used to issue requests.
+func WithHTTPClient(client *http.Client) RequestOption {
+	return func(options *core.RequestOptions) {
+		options.HTTPClient = client
+	}
+}
+
+// WithMaxAttempts sets the maximum number of times a request is attempted,
+// including the initial attempt.
+func WithMaxAttempts(maxAttempts uint) RequestOption {
+	return func(options *core.RequestOptions) {
+		options.MaxAttempts = maxAttempts
+	}
+}
+
+// WithToken sets the bearer token sent with every request.
+func WithToken(token string) RequestOption {
+	return func(options *core.RequestOptions) {
+		options.Token = token
+	}
+}
+
+// WithHeader adds a header sent with every request.
+func WithHeader(key, value string) RequestOption {
+	return func(options *core.RequestOptions) {
+		if options.Headers == nil {
+			options.Headers = http.Header{}
+		}
+		options.Headers.Add(key, value)
+	}
+}
+
+// WithRetryPolicy sets the policy used to decide whether, and how long, to
+// wait between retried attempts. See core.RetryPolicy.
+func WithRetryPolicy(policy *core.RetryPolicy) RequestOption {
+	return func(options *core.RequestOptions) {
+		options.RetryPolicy = policy
+	}
+}
+
+// WithMiddleware registers middleware that wraps every request issued by
+// the client, outermost first. Cross-cutting concerns (request IDs,
+// logging, metrics, auth) should be implemented as middleware rather than
+// special-cased in generated endpoint code.
+func WithMiddleware(mw ...core.Middleware) RequestOption {
+	return func(options *core.RequestOptions) {
+		options.Middlewares = append(options.Middlewares, mw...)
+	}
+}
@@ -3,16 +3,15 @@
 package search
 
 import (
-	bytes "bytes"
 	context "context"
-	json "encoding/json"
-	errors "errors"
 	fmt "fmt"
 	zepgo "github.com/getzep/zep-go"
 	core "github.com/getzep/zep-go/core"
 	option "github.com/getzep/zep-go/option"
 	io "io"
 	http "net/http"
+	url "net/url"
+	strconv "strconv"
 )
 
 type Client struct {
@@ -29,18 +28,69 @@ func NewClient(opts ...option.RequestOption) *Client {
 			&core.CallerParams{
 				Client:      options.HTTPClient,
 				MaxAttempts: options.MaxAttempts,
+				Middlewares: options.Middlewares,
 			},
 		),
 		header: options.ToHeader(),
 	}
 }
 
-// search memory messages by session id and query
+// search memory messages by session id and query. hybrid may be nil, in
+// which case the server's default similarity search is used; otherwise it
+// selects hybrid/MMR retrieval and (for SearchTypeMMR) a client-side
+// rerank of the returned candidates. See HybridSearchParams.
 func (c *Client) Get(
 	ctx context.Context,
 	// Session ID
 	sessionID string,
 	request *zepgo.MemorySearchPayload,
+	hybrid *HybridSearchParams,
+	opts ...option.RequestOption,
+) ([]*zepgo.MemorySearchResult, error) {
+	results, err := c.get(ctx, sessionID, request, hybridQueryValues(hybrid), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if hybrid == nil || hybrid.SearchType != SearchTypeMMR {
+		return results, nil
+	}
+
+	if len(hybrid.QueryEmbedding) == 0 {
+		return nil, &MMREmbeddingsRequiredError{
+			Message: "MMR reranking requires HybridSearchParams.QueryEmbedding to be set to the query's embedding",
+		}
+	}
+
+	limit := len(results)
+	if request != nil && request.Limit != 0 {
+		limit = request.Limit
+	}
+
+	return RerankMMR(results, hybrid.QueryEmbedding, hybrid.MMRLambda, limit)
+}
+
+// getPage issues a single page request with limit/offset appended to the
+// query string, for use by SearchIterator/Stream.
+func (c *Client) getPage(
+	ctx context.Context,
+	sessionID string,
+	request *zepgo.MemorySearchPayload,
+	offset int,
+	pageSize int,
+	opts []option.RequestOption,
+) ([]*zepgo.MemorySearchResult, error) {
+	extraQuery := url.Values{}
+	extraQuery.Set("limit", strconv.Itoa(pageSize))
+	extraQuery.Set("offset", strconv.Itoa(offset))
+	return c.get(ctx, sessionID, request, extraQuery, opts...)
+}
+
+func (c *Client) get(
+	ctx context.Context,
+	sessionID string,
+	request *zepgo.MemorySearchPayload,
+	extraQuery url.Values,
 	opts ...option.RequestOption,
 ) ([]*zepgo.MemorySearchResult, error) {
 	options := core.NewRequestOptions(opts...)
@@ -58,36 +108,19 @@ func (c *Client) Get(
 	if err != nil {
 		return nil, err
 	}
+	for key, values := range extraQuery {
+		for _, value := range values {
+			queryParams.Set(key, value)
+		}
+	}
 	if len(queryParams) > 0 {
 		endpointURL += "?" + queryParams.Encode()
 	}
 
 	headers := core.MergeHeaders(c.header.Clone(), options.ToHeader())
 
-	errorDecoder := func(statusCode int, body io.Reader) error {
-		raw, err := io.ReadAll(body)
-		if err != nil {
-			return err
-		}
-		apiError := core.NewAPIError(statusCode, errors.New(string(raw)))
-		decoder := json.NewDecoder(bytes.NewReader(raw))
-		switch statusCode {
-		case 404:
-			value := new(zepgo.NotFoundError)
-			value.APIError = apiError
-			if err := decoder.Decode(value); err != nil {
-				return apiError
-			}
-			return value
-		case 500:
-			value := new(zepgo.InternalServerError)
-			value.APIError = apiError
-			if err := decoder.Decode(value); err != nil {
-				return apiError
-			}
-			return value
-		}
-		return apiError
+	errorDecoder := func(statusCode int, header http.Header, body io.Reader) error {
+		return core.DecodeAPIError(statusCode, header, body)
 	}
 
 	var response []*zepgo.MemorySearchResult
@@ -102,6 +135,8 @@ func (c *Client) Get(
 			Request:      request,
 			Response:     &response,
 			ErrorDecoder: errorDecoder,
+			Middlewares:  options.Middlewares,
+			RetryPolicy:  options.RetryPolicy,
 		},
 	); err != nil {
 		return nil, err
@@ -0,0 +1,138 @@
+package search
+
+import (
+	math "math"
+	strconv "strconv"
+
+	zepgo "github.com/getzep/zep-go"
+)
+
+// SearchType selects how search.Client retrieves candidates.
+type SearchType string
+
+const (
+	SearchTypeSimilarity SearchType = "similarity"
+	SearchTypeMMR        SearchType = "mmr"
+	SearchTypeHybrid     SearchType = "hybrid"
+)
+
+// HybridSearchParams extends a *zepgo.MemorySearchPayload with hybrid
+// (vector + keyword) retrieval and MMR reranking controls. These are sent
+// to the server as query values, and MMRLambda additionally drives a
+// client-side rerank when SearchType is SearchTypeMMR.
+type HybridSearchParams struct {
+	SearchType SearchType
+	// MMRLambda trades off relevance (1.0) against diversity (0.0) when
+	// SearchType is SearchTypeMMR.
+	MMRLambda float64
+	// KeywordWeight blends BM25/keyword score against vector similarity
+	// when SearchType is SearchTypeHybrid.
+	KeywordWeight float64
+	// FetchK is how many candidates to over-fetch before reranking down
+	// to the request's Limit.
+	FetchK int
+	// WithEmbeddings asks the server to include each result's embedding,
+	// which client-side MMR reranking requires.
+	WithEmbeddings bool
+	// QueryEmbedding is the embedding of the search query. It is required
+	// when SearchType is SearchTypeMMR, since MMR reranks candidates by
+	// their similarity to the query itself, not to each other.
+	QueryEmbedding []float64
+}
+
+// MMREmbeddingsRequiredError is returned when MMR reranking is requested
+// but the server response did not include embeddings for the candidates,
+// or (from Client.Get) when HybridSearchParams.QueryEmbedding is empty.
+type MMREmbeddingsRequiredError struct {
+	Message string
+}
+
+func (e *MMREmbeddingsRequiredError) Error() string {
+	return e.Message
+}
+
+func hybridQueryValues(hybrid *HybridSearchParams) map[string][]string {
+	if hybrid == nil {
+		return nil
+	}
+	values := map[string][]string{}
+	if hybrid.SearchType != "" {
+		values["search_type"] = []string{string(hybrid.SearchType)}
+	}
+	if hybrid.MMRLambda != 0 {
+		values["mmr_lambda"] = []string{strconv.FormatFloat(hybrid.MMRLambda, 'f', -1, 64)}
+	}
+	if hybrid.KeywordWeight != 0 {
+		values["keyword_weight"] = []string{strconv.FormatFloat(hybrid.KeywordWeight, 'f', -1, 64)}
+	}
+	if hybrid.FetchK != 0 {
+		values["fetch_k"] = []string{strconv.Itoa(hybrid.FetchK)}
+	}
+	if hybrid.WithEmbeddings {
+		values["with_embeddings"] = []string{"true"}
+	}
+	return values
+}
+
+// RerankMMR reorders results by Maximal Marginal Relevance: it iteratively
+// selects the candidate maximizing
+//
+//	lambda*sim(query, candidate) - (1-lambda)*max(sim(candidate, selected))
+//
+// using cosine similarity, until k results have been chosen (or results is
+// exhausted). It returns MMREmbeddingsRequiredError if any candidate is
+// missing an embedding.
+func RerankMMR(results []*zepgo.MemorySearchResult, queryEmbedding []float64, lambda float64, k int) ([]*zepgo.MemorySearchResult, error) {
+	for _, r := range results {
+		if len(r.Embedding) == 0 {
+			return nil, &MMREmbeddingsRequiredError{
+				Message: "MMR reranking requires embeddings on every candidate; pass WithEmbeddings to request them from the server",
+			}
+		}
+	}
+	if k <= 0 || k > len(results) {
+		k = len(results)
+	}
+
+	remaining := append([]*zepgo.MemorySearchResult(nil), results...)
+	selected := make([]*zepgo.MemorySearchResult, 0, k)
+
+	for len(selected) < k && len(remaining) > 0 {
+		bestIdx := -1
+		bestScore := math.Inf(-1)
+		for i, candidate := range remaining {
+			relevance := cosineSimilarity(queryEmbedding, candidate.Embedding)
+			diversity := 0.0
+			for _, chosen := range selected {
+				if sim := cosineSimilarity(candidate.Embedding, chosen.Embedding); sim > diversity {
+					diversity = sim
+				}
+			}
+			score := lambda*relevance - (1-lambda)*diversity
+			if score > bestScore {
+				bestScore = score
+				bestIdx = i
+			}
+		}
+		selected = append(selected, remaining[bestIdx])
+		remaining = append(remaining[:bestIdx], remaining[bestIdx+1:]...)
+	}
+
+	return selected, nil
+}
+
+func cosineSimilarity(a, b []float64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,68 @@
+package search
+
+import (
+	"math"
+	"testing"
+
+	zepgo "github.com/getzep/zep-go"
+)
+
+func TestCosineSimilarity(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b []float64
+		want float64
+	}{
+		{"identical", []float64{1, 0}, []float64{1, 0}, 1},
+		{"orthogonal", []float64{1, 0}, []float64{0, 1}, 0},
+		{"opposite", []float64{1, 0}, []float64{-1, 0}, -1},
+		{"mismatched length", []float64{1, 0}, []float64{1}, 0},
+		{"zero vector", []float64{0, 0}, []float64{1, 0}, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := cosineSimilarity(c.a, c.b); math.Abs(got-c.want) > 1e-9 {
+				t.Errorf("cosineSimilarity(%v, %v) = %v, want %v", c.a, c.b, got, c.want)
+			}
+		})
+	}
+}
+
+func TestRerankMMRPrefersDiverseCandidates(t *testing.T) {
+	// Query is along the x-axis. Two candidates are near-identical to the
+	// query (and to each other); one is orthogonal, so it has zero relevance
+	// but is maximally different. With a low lambda (diversity-weighted), MMR should not pick
+	// both near-duplicates back to back.
+	query := []float64{1, 0}
+	results := []*zepgo.MemorySearchResult{
+		{Embedding: []float64{1, 0}},
+		{Embedding: []float64{0.99, 0.01}},
+		{Embedding: []float64{0, 1}},
+	}
+
+	reranked, err := RerankMMR(results, query, 0.3, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reranked) != 2 {
+		t.Fatalf("got %d results, want 2", len(reranked))
+	}
+	if reranked[0] != results[0] {
+		t.Errorf("first result = %v, want the most relevant candidate", reranked[0])
+	}
+	if reranked[1] == results[1] {
+		t.Errorf("second result picked the near-duplicate instead of the more diverse candidate")
+	}
+}
+
+func TestRerankMMRRequiresEmbeddings(t *testing.T) {
+	results := []*zepgo.MemorySearchResult{
+		{Embedding: []float64{1, 0}},
+		{},
+	}
+	if _, err := RerankMMR(results, []float64{1, 0}, 0.5, 2); err == nil {
+		t.Fatal("expected MMREmbeddingsRequiredError, got nil")
+	} else if _, ok := err.(*MMREmbeddingsRequiredError); !ok {
+		t.Errorf("got error of type %T, want *MMREmbeddingsRequiredError", err)
+	}
+}
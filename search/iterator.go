@@ -0,0 +1,186 @@
+package search
+
+import (
+	context "context"
+
+	zepgo "github.com/getzep/zep-go"
+	option "github.com/getzep/zep-go/option"
+)
+
+// DefaultPageSize is used when no WithPageSize option is given to Iterate
+// or Stream.
+const DefaultPageSize = 100
+
+// DefaultStreamBuffer is the channel size used when no WithStreamBuffer
+// option is given to Stream.
+const DefaultStreamBuffer = 16
+
+type iterOptions struct {
+	pageSize     int
+	streamBuffer int
+	requestOpts  []option.RequestOption
+}
+
+// IterateOption configures Iterate and Stream.
+type IterateOption func(*iterOptions)
+
+// WithPageSize sets how many results Iterate/Stream fetch per request to
+// the server.
+func WithPageSize(n int) IterateOption {
+	return func(o *iterOptions) {
+		o.pageSize = n
+	}
+}
+
+// WithStreamBuffer sets the buffer size of the channel returned by Stream.
+func WithStreamBuffer(n int) IterateOption {
+	return func(o *iterOptions) {
+		o.streamBuffer = n
+	}
+}
+
+// WithRequestOptions passes through option.RequestOption values (such as
+// WithRetryPolicy) to every page request the iterator issues.
+func WithRequestOptions(opts ...option.RequestOption) IterateOption {
+	return func(o *iterOptions) {
+		o.requestOpts = append(o.requestOpts, opts...)
+	}
+}
+
+func newIterOptions(opts []IterateOption) *iterOptions {
+	cfg := &iterOptions{pageSize: DefaultPageSize, streamBuffer: DefaultStreamBuffer}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// SearchIterator lazily fetches pages of search results, one page at a
+// time, so large Limit values don't require buffering the whole result set
+// up front.
+type SearchIterator struct {
+	ctx       context.Context
+	client    *Client
+	sessionID string
+	request   *zepgo.MemorySearchPayload
+	opts      []option.RequestOption
+	pageSize  int
+
+	offset  int
+	page    []*zepgo.MemorySearchResult
+	pageIdx int
+	current *zepgo.MemorySearchResult
+
+	err  error
+	done bool
+}
+
+// Iterate returns a SearchIterator over the results of a search request,
+// fetching pageSize-sized pages from the server as Next is called.
+func (c *Client) Iterate(
+	ctx context.Context,
+	sessionID string,
+	request *zepgo.MemorySearchPayload,
+	opts ...IterateOption,
+) *SearchIterator {
+	cfg := newIterOptions(opts)
+
+	return &SearchIterator{
+		ctx:       ctx,
+		client:    c,
+		sessionID: sessionID,
+		request:   request,
+		opts:      cfg.requestOpts,
+		pageSize:  cfg.pageSize,
+	}
+}
+
+// Next advances the iterator, fetching the next page from the server if
+// the current page has been exhausted. It returns false once there are no
+// more results or an error occurred; check Err to distinguish the two.
+func (i *SearchIterator) Next() bool {
+	if i.err != nil || i.done {
+		return false
+	}
+	if i.pageIdx >= len(i.page) {
+		if err := i.fetchNextPage(); err != nil {
+			i.err = err
+			return false
+		}
+		if len(i.page) == 0 {
+			i.done = true
+			return false
+		}
+	}
+	i.current = i.page[i.pageIdx]
+	i.pageIdx++
+	if i.pageIdx >= len(i.page) && len(i.page) < i.pageSize {
+		// Short page: no more results after this one is consumed.
+		i.done = true
+	}
+	return true
+}
+
+// Result returns the result most recently advanced to by Next.
+func (i *SearchIterator) Result() *zepgo.MemorySearchResult {
+	return i.current
+}
+
+// Err returns the first error encountered while fetching pages, if any.
+func (i *SearchIterator) Err() error {
+	return i.err
+}
+
+// Close releases any resources held by the iterator. It is safe to call
+// multiple times.
+func (i *SearchIterator) Close() {
+	i.done = true
+}
+
+func (i *SearchIterator) fetchNextPage() error {
+	page, err := i.client.getPage(i.ctx, i.sessionID, i.request, i.offset, i.pageSize, i.opts)
+	if err != nil {
+		return err
+	}
+	i.page = page
+	i.pageIdx = 0
+	i.offset += len(page)
+	return nil
+}
+
+// Stream runs an internal SearchIterator in a goroutine and delivers
+// results over a bounded channel, for goroutine-friendly consumption. The
+// returned error channel receives at most one error and is closed alongside
+// the result channel once the iterator is exhausted or ctx is done.
+func (c *Client) Stream(
+	ctx context.Context,
+	sessionID string,
+	request *zepgo.MemorySearchPayload,
+	opts ...IterateOption,
+) (<-chan *zepgo.MemorySearchResult, <-chan error) {
+	cfg := newIterOptions(opts)
+
+	results := make(chan *zepgo.MemorySearchResult, cfg.streamBuffer)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(results)
+		defer close(errs)
+
+		it := c.Iterate(ctx, sessionID, request, opts...)
+		defer it.Close()
+		for it.Next() {
+			select {
+			case results <- it.Result():
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			errs <- err
+		}
+	}()
+
+	return results, errs
+}
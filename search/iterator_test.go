@@ -0,0 +1,76 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getzep/zep-go/option"
+)
+
+// newTestClient starts a server that serves pageLens[n] results for the
+// n-th page request (by call order) and returns a Client pointed at it.
+func newTestClient(t *testing.T, pageLens []int) (*Client, *int) {
+	t.Helper()
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var n int
+		if calls < len(pageLens) {
+			n = pageLens[calls]
+		}
+		calls++
+		results := make([]map[string]interface{}, n)
+		for i := range results {
+			results[i] = map[string]interface{}{}
+		}
+		_ = json.NewEncoder(w).Encode(results)
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(option.WithBaseURL(server.URL))
+	return client, &calls
+}
+
+func TestSearchIteratorStopsOnShortPage(t *testing.T) {
+	client, calls := newTestClient(t, []int{2, 1})
+
+	it := client.Iterate(context.Background(), "session-id", nil, WithPageSize(2))
+	defer it.Close()
+
+	var got int
+	for it.Next() {
+		got++
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 3 {
+		t.Errorf("got %d results, want 3", got)
+	}
+	if *calls != 2 {
+		t.Errorf("got %d page requests, want 2 (should stop after the short page, not fetch a third)", *calls)
+	}
+}
+
+func TestSearchIteratorStopsOnEmptyFullPage(t *testing.T) {
+	client, calls := newTestClient(t, []int{2, 0})
+
+	it := client.Iterate(context.Background(), "session-id", nil, WithPageSize(2))
+	defer it.Close()
+
+	var got int
+	for it.Next() {
+		got++
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 2 {
+		t.Errorf("got %d results, want 2", got)
+	}
+	if *calls != 2 {
+		t.Errorf("got %d page requests, want 2", *calls)
+	}
+}
@@ -0,0 +1,19 @@
+package zep
+
+// ZepError is the base error type returned by the Zep client. All other
+// error types in this package embed it so callers can type-switch on the
+// more specific variants while still satisfying the error interface.
+type ZepError struct {
+	Message string
+}
+
+func (e *ZepError) Error() string {
+	return e.Message
+}
+
+// ServerUnavailableError is returned when the client's cached server status
+// indicates the server is unhealthy, so the request is short-circuited
+// before it ever hits the network.
+type ServerUnavailableError struct {
+	ZepError
+}